@@ -0,0 +1,32 @@
+// Copyright 2022 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package primitive
+
+// Subscription describes what a trigger worker needs to run one subscription:
+// where events come from (handled upstream), how they are filtered, and
+// where they are delivered.
+type Subscription struct {
+	ID      string        `json:"id"`
+	Sink    URI           `json:"sink"`
+	Filters []interface{} `json:"filters"`
+
+	// DeadLetter is the sink events are forwarded to once a delivery has
+	// exhausted its retries. Empty means no dead-letter handling.
+	DeadLetter URI `json:"dead_letter,omitempty"`
+
+	// Transformer, when set, is applied to every event that passes
+	// filtering, before it is delivered to Sink.
+	Transformer *SubscriptionTransformer `json:"transformer,omitempty"`
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package action defines the unit a transform.Pipeline is built from: a
+// single named mutation applied to one CloudEvent.
+package action
+
+import (
+	ce "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/linkall-labs/vanus/internal/primitive/transform/arg"
+	"github.com/linkall-labs/vanus/internal/primitive/transform/function"
+)
+
+// Action mutates a single CloudEvent as one step of a transform pipeline.
+type Action interface {
+	// Name returns the action's registered name, e.g. "CAPITALIZE_WORD".
+	Name() string
+	// Init binds FixedArgs to the concrete paths/values given in a
+	// subscription's transformer spec.
+	Init(args []string) error
+	// Execute applies the action to event in place.
+	Execute(event *ce.Event) error
+}
+
+// CommonAction holds the parts shared by every Action: its registered name,
+// the shape of the arguments it expects, and the function it applies to
+// them.
+type CommonAction struct {
+	ActionName string
+	FixedArgs  []arg.TypeList
+	Fn         function.Function
+
+	path string
+}
+
+func (c *CommonAction) Name() string {
+	return c.ActionName
+}
+
+func (c *CommonAction) Init(args []string) error {
+	if len(args) > 0 {
+		c.path = args[0]
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+)
+
+// eventStringValue reads a string value at key from event's data, assumed
+// to be a JSON object, falling back to an extension attribute of the same
+// name.
+func eventStringValue(event *ce.Event, key string) (string, error) {
+	var data map[string]interface{}
+	if err := json.Unmarshal(event.Data(), &data); err == nil {
+		if v, ok := data[key].(string); ok {
+			return v, nil
+		}
+	}
+	if v, ok := event.Extensions()[key].(string); ok {
+		return v, nil
+	}
+	return "", fmt.Errorf("action: key %q not found on event", key)
+}
+
+// setEventStringValue writes value back to the same place eventStringValue
+// would have read it from: the data field if present there, otherwise an
+// extension attribute.
+func setEventStringValue(event *ce.Event, key, value string) error {
+	var data map[string]interface{}
+	if err := json.Unmarshal(event.Data(), &data); err == nil {
+		if _, ok := data[key].(string); ok {
+			data[key] = value
+			b, err := json.Marshal(data)
+			if err != nil {
+				return err
+			}
+			return event.SetData(ce.ApplicationJSON, b)
+		}
+	}
+	event.SetExtension(key, value)
+	return nil
+}
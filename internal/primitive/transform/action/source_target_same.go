@@ -0,0 +1,40 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package action
+
+import (
+	"fmt"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+)
+
+// SourceTargetSameAction is an Action whose single argument names both the
+// field it reads from and the field it writes back to, e.g. CAPITALIZE_WORD
+// mutating "key" in place.
+type SourceTargetSameAction struct {
+	CommonAction
+}
+
+func (a *SourceTargetSameAction) Execute(event *ce.Event) error {
+	value, err := eventStringValue(event, a.path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", a.ActionName, err)
+	}
+	result, err := a.Fn(value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", a.ActionName, err)
+	}
+	return setEventStringValue(event, a.path, result)
+}
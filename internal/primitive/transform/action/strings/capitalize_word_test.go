@@ -0,0 +1,70 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"testing"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+)
+
+func TestCapitalizeWordAction_ExecuteMutatesDataField(t *testing.T) {
+	a := NewCapitalizeWordAction()
+	if err := a.Init([]string{"name"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Name() != "CAPITALIZE_WORD" {
+		t.Fatalf("expected name %q, got %q", "CAPITALIZE_WORD", a.Name())
+	}
+
+	event := ce.NewEvent()
+	event.SetID("1")
+	event.SetType("test.type")
+	event.SetSource("test.source")
+	if err := event.SetData(ce.ApplicationJSON, map[string]string{"name": "bob"}); err != nil {
+		t.Fatalf("failed to set event data: %v", err)
+	}
+
+	if err := a.Execute(&event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var data map[string]string
+	if err := event.DataAs(&data); err != nil {
+		t.Fatalf("failed to read back event data: %v", err)
+	}
+	if data["name"] != "Bob" {
+		t.Fatalf("expected data.name to be capitalized to %q, got %q", "Bob", data["name"])
+	}
+}
+
+func TestCapitalizeWordAction_ExecuteErrorsOnMissingField(t *testing.T) {
+	a := NewCapitalizeWordAction()
+	if err := a.Init([]string{"missing"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	event := ce.NewEvent()
+	event.SetID("1")
+	event.SetType("test.type")
+	event.SetSource("test.source")
+	if err := event.SetData(ce.ApplicationJSON, map[string]string{"name": "bob"}); err != nil {
+		t.Fatalf("failed to set event data: %v", err)
+	}
+
+	if err := a.Execute(&event); err == nil {
+		t.Fatalf("expected an error when the field is missing from the event")
+	}
+}
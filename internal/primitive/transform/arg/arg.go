@@ -0,0 +1,29 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package arg describes where an action.Action's arguments come from.
+package arg
+
+// TypeList enumerates the source an action.Action reads one of its
+// FixedArgs from.
+type TypeList string
+
+const (
+	// EventList means the argument is a path into the event itself: a
+	// data field or an extension attribute.
+	EventList TypeList = "event"
+	// ConstList means the argument is a literal value taken verbatim from
+	// the subscription's transformer spec.
+	ConstList TypeList = "const"
+)
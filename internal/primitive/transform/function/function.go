@@ -0,0 +1,28 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package function holds the plain string transforms that action.Action
+// implementations apply to event fields.
+package function
+
+import "strings"
+
+// Function transforms a single value read from an event into the value an
+// action.Action writes back.
+type Function func(value string) (string, error)
+
+// CapitalizeWord capitalizes the first letter of every word in value.
+func CapitalizeWord(value string) (string, error) {
+	return strings.Title(value), nil
+}
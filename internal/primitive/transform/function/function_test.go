@@ -0,0 +1,35 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import "testing"
+
+func TestCapitalizeWord(t *testing.T) {
+	cases := map[string]string{
+		"alice":      "Alice",
+		"alice bob":  "Alice Bob",
+		"":           "",
+		"ALREADY UP": "ALREADY UP",
+	}
+	for in, want := range cases {
+		got, err := CapitalizeWord(in)
+		if err != nil {
+			t.Fatalf("CapitalizeWord(%q) returned error: %v", in, err)
+		}
+		if got != want {
+			t.Fatalf("CapitalizeWord(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
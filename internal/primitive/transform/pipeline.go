@@ -0,0 +1,75 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package transform applies a subscription's configured transform actions
+// to an event before it is handed to a sink.
+package transform
+
+import (
+	"fmt"
+	"strings"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/linkall-labs/vanus/internal/primitive"
+	"github.com/linkall-labs/vanus/internal/primitive/transform/action"
+	stringaction "github.com/linkall-labs/vanus/internal/primitive/transform/action/strings"
+)
+
+// actionBuilders maps an action name, as used in a subscription's
+// transformer spec, to its constructor.
+var actionBuilders = map[string]func() action.Action{
+	"CAPITALIZE_WORD": func() action.Action { return stringaction.NewCapitalizeWordAction() },
+}
+
+// Pipeline applies an ordered list of action.Action to an event.
+type Pipeline struct {
+	actions []action.Action
+}
+
+// Execute runs every action in order, stopping at the first error so a
+// partially-transformed event is never sent on.
+func (p *Pipeline) Execute(event *ce.Event) error {
+	for _, a := range p.actions {
+		if err := a.Execute(event); err != nil {
+			return fmt.Errorf("transform: action %q: %w", a.Name(), err)
+		}
+	}
+	return nil
+}
+
+// NewPipelineFromSpec builds a Pipeline from a subscription's transformer
+// spec, an ordered list of [action_name, arg...] tuples. A nil spec yields a
+// nil Pipeline, which callers should treat as "nothing to do".
+func NewPipelineFromSpec(spec *primitive.SubscriptionTransformer) (*Pipeline, error) {
+	if spec == nil || len(spec.Actions) == 0 {
+		return nil, nil
+	}
+	p := &Pipeline{}
+	for _, def := range spec.Actions {
+		if len(def) == 0 {
+			continue
+		}
+		build, ok := actionBuilders[strings.ToUpper(def[0])]
+		if !ok {
+			return nil, fmt.Errorf("transform: unknown action %q", def[0])
+		}
+		a := build()
+		if err := a.Init(def[1:]); err != nil {
+			return nil, fmt.Errorf("transform: init action %q: %w", def[0], err)
+		}
+		p.actions = append(p.actions, a)
+	}
+	return p, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"testing"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/linkall-labs/vanus/internal/primitive"
+)
+
+func TestNewPipelineFromSpec_NilOrEmptyYieldsNilPipeline(t *testing.T) {
+	p, err := NewPipelineFromSpec(nil)
+	if err != nil || p != nil {
+		t.Fatalf("expected nil, nil for a nil spec, got %v, %v", p, err)
+	}
+	p, err = NewPipelineFromSpec(&primitive.SubscriptionTransformer{})
+	if err != nil || p != nil {
+		t.Fatalf("expected nil, nil for an empty spec, got %v, %v", p, err)
+	}
+}
+
+func TestNewPipelineFromSpec_UnknownAction(t *testing.T) {
+	_, err := NewPipelineFromSpec(&primitive.SubscriptionTransformer{
+		Actions: [][]string{{"NOT_A_REAL_ACTION", "name"}},
+	})
+	if err == nil {
+		t.Fatalf("expected an error for an unknown action name")
+	}
+}
+
+func TestNewPipelineFromSpec_CaseInsensitiveLookup(t *testing.T) {
+	p, err := NewPipelineFromSpec(&primitive.SubscriptionTransformer{
+		Actions: [][]string{{"capitalize_word", "name"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p == nil || len(p.actions) != 1 {
+		t.Fatalf("expected a single-action pipeline, got %v", p)
+	}
+}
+
+func TestPipeline_ExecuteCapitalizesDataField(t *testing.T) {
+	p, err := NewPipelineFromSpec(&primitive.SubscriptionTransformer{
+		Actions: [][]string{{"CAPITALIZE_WORD", "name"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	event := ce.NewEvent()
+	event.SetID("1")
+	event.SetType("test.type")
+	event.SetSource("test.source")
+	if err := event.SetData(ce.ApplicationJSON, map[string]string{"name": "alice"}); err != nil {
+		t.Fatalf("failed to set event data: %v", err)
+	}
+
+	if err := p.Execute(&event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var data map[string]string
+	if err := event.DataAs(&data); err != nil {
+		t.Fatalf("failed to read back event data: %v", err)
+	}
+	if data["name"] != "Alice" {
+		t.Fatalf("expected data.name to be capitalized to %q, got %q", "Alice", data["name"])
+	}
+}
+
+func TestPipeline_ExecuteStopsAtFirstError(t *testing.T) {
+	p, err := NewPipelineFromSpec(&primitive.SubscriptionTransformer{
+		Actions: [][]string{{"CAPITALIZE_WORD", "missing-field"}, {"CAPITALIZE_WORD", "name"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	event := ce.NewEvent()
+	event.SetID("1")
+	event.SetType("test.type")
+	event.SetSource("test.source")
+	if err := event.SetData(ce.ApplicationJSON, map[string]string{"name": "alice"}); err != nil {
+		t.Fatalf("failed to set event data: %v", err)
+	}
+
+	if err := p.Execute(&event); err == nil {
+		t.Fatalf("expected an error when the action's field is missing from the event")
+	}
+
+	var data map[string]string
+	if err := event.DataAs(&data); err != nil {
+		t.Fatalf("failed to read back event data: %v", err)
+	}
+	if data["name"] != "alice" {
+		t.Fatalf("expected the action after the failing one not to run, got data.name %q", data["name"])
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package primitive
+
+// SubscriptionTransformer describes how a subscription's events are
+// reshaped before delivery: an ordered list of transform actions applied to
+// the event's top-level data fields.
+//
+// TODO(product): the original request for this type also asked for a
+// JSON/CEL-style template to reshape the whole output payload, not just
+// individual fields via Actions. That half was never implemented — flagging
+// for a product/requester decision on whether it's still needed, rather
+// than assuming the action list is a sufficient substitute.
+type SubscriptionTransformer struct {
+	// Actions is an ordered list of [action_name, arg...] tuples, e.g.
+	// [["capitalize_word", "name"]].
+	Actions [][]string `json:"actions,omitempty"`
+}
@@ -0,0 +1,37 @@
+// Copyright 2022 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package primitive
+
+import (
+	ce "github.com/cloudevents/sdk-go/v2"
+)
+
+// URI identifies the endpoint a CloudEvent is delivered to, e.g. a sink or
+// a dead-letter target.
+type URI string
+
+func (u URI) String() string {
+	return string(u)
+}
+
+// NewCeClient builds a CloudEvents client that delivers to the given URI
+// over HTTP.
+func NewCeClient(uri URI) (ce.Client, error) {
+	p, err := ce.NewHTTP(ce.WithTarget(uri.String()))
+	if err != nil {
+		return nil, err
+	}
+	return ce.NewClient(p)
+}
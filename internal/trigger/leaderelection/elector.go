@@ -0,0 +1,38 @@
+// Copyright 2022 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package leaderelection lets several replicas of the same trigger worker
+// agree on a single active leader for a subscription, so that only one of
+// them delivers events at a time. It is built on vanus' own metadata/etcd
+// layer rather than Kubernetes Lease objects, so it needs no extra RBAC in
+// clusters where the trigger worker isn't allowed to manage Leases.
+package leaderelection
+
+import "context"
+
+// LeaderElector campaigns for leadership of a single named resource. A
+// Trigger holds one per subscription, keyed by SubscriptionID.
+type LeaderElector interface {
+	// Campaign blocks until leadership is acquired or ctx is cancelled.
+	Campaign(ctx context.Context) error
+
+	// Status returns a channel that emits the current leadership state on
+	// every change. A false value means leadership has been lost and will
+	// not be reacquired automatically; the caller must Campaign again.
+	Status() <-chan bool
+
+	// Resign gives up leadership, if currently held, and releases any
+	// underlying resources.
+	Resign(ctx context.Context) error
+}
@@ -0,0 +1,93 @@
+// Copyright 2022 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leaderelection
+
+import (
+	"context"
+	"path"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+
+	"github.com/linkall-labs/vanus/observability/log"
+)
+
+const keyPrefix = "/vanus/trigger/leader"
+
+// EtcdElector elects a leader among replicas of the same trigger worker
+// using an etcd session/election, reusing the cluster's existing etcd
+// client rather than a Kubernetes Lease, so no additional RBAC is needed.
+type EtcdElector struct {
+	client   *clientv3.Client
+	key      string
+	session  *concurrency.Session
+	election *concurrency.Election
+	statusCh chan bool
+}
+
+// NewEtcdElector creates a LeaderElector for the given resource name (e.g. a
+// subscription ID), backed by client.
+func NewEtcdElector(client *clientv3.Client, name string) *EtcdElector {
+	return &EtcdElector{
+		client:   client,
+		key:      path.Join(keyPrefix, name),
+		statusCh: make(chan bool, 1),
+	}
+}
+
+func (e *EtcdElector) Campaign(ctx context.Context) error {
+	session, err := concurrency.NewSession(e.client)
+	if err != nil {
+		return err
+	}
+	e.session = session
+	e.election = concurrency.NewElection(session, e.key)
+
+	if err := e.election.Campaign(ctx, e.key); err != nil {
+		session.Close()
+		return err
+	}
+	e.statusCh <- true
+
+	go e.watchLoss(context.Background())
+	return nil
+}
+
+// watchLoss observes the etcd session and reports the loss of leadership,
+// e.g. because of a lost connection or an expired lease, so the caller can
+// stop delivering events.
+func (e *EtcdElector) watchLoss(ctx context.Context) {
+	select {
+	case <-e.session.Done():
+		log.Info(ctx, "leader election session closed, leadership lost", map[string]interface{}{
+			"key": e.key,
+		})
+		e.statusCh <- false
+	}
+}
+
+func (e *EtcdElector) Status() <-chan bool {
+	return e.statusCh
+}
+
+func (e *EtcdElector) Resign(ctx context.Context) error {
+	if e.election == nil {
+		return nil
+	}
+	if err := e.election.Resign(ctx); err != nil {
+		return err
+	}
+	return e.session.Close()
+}
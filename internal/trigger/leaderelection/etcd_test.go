@@ -0,0 +1,141 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leaderelection
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/embed"
+)
+
+// startEmbeddedEtcd starts a single-node etcd server in a temp dir and
+// returns a client connected to it, so EtcdElector can be exercised against
+// a real election instead of a fake Status channel.
+func startEmbeddedEtcd(t *testing.T) *clientv3.Client {
+	t.Helper()
+	dir := t.TempDir()
+	clientURL := localURL(t)
+	peerURL := localURL(t)
+
+	cfg := embed.NewConfig()
+	cfg.Dir = dir
+	cfg.LCUrls = []url.URL{*clientURL}
+	cfg.ACUrls = []url.URL{*clientURL}
+	cfg.LPUrls = []url.URL{*peerURL}
+	cfg.APUrls = []url.URL{*peerURL}
+	cfg.InitialCluster = fmt.Sprintf("default=%s", peerURL.String())
+	cfg.LogLevel = "error"
+
+	e, err := embed.StartEtcd(cfg)
+	if err != nil {
+		t.Fatalf("failed to start embedded etcd: %v", err)
+	}
+	t.Cleanup(e.Close)
+
+	select {
+	case <-e.Server.ReadyNotify():
+	case <-time.After(10 * time.Second):
+		t.Fatalf("embedded etcd took too long to become ready")
+	}
+
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   []string{clientURL.String()},
+		DialTimeout: 5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("failed to create etcd client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// localURL picks a free localhost port so parallel test runs don't collide.
+func localURL(t *testing.T) *url.URL {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	defer l.Close()
+	u, err := url.Parse(fmt.Sprintf("http://%s", l.Addr().String()))
+	if err != nil {
+		t.Fatalf("failed to build url: %v", err)
+	}
+	return u
+}
+
+func TestEtcdElector_CampaignWinsAndResignReleases(t *testing.T) {
+	client := startEmbeddedEtcd(t)
+	e := NewEtcdElector(client, "sub-1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := e.Campaign(ctx); err != nil {
+		t.Fatalf("Campaign failed: %v", err)
+	}
+
+	select {
+	case leading := <-e.Status():
+		if !leading {
+			t.Fatalf("expected to win leadership")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected an immediate leadership status update")
+	}
+
+	if err := e.Resign(ctx); err != nil {
+		t.Fatalf("Resign failed: %v", err)
+	}
+}
+
+func TestEtcdElector_SecondCampaignWaitsForFirstToResign(t *testing.T) {
+	client := startEmbeddedEtcd(t)
+
+	first := NewEtcdElector(client, "sub-2")
+	if err := first.Campaign(context.Background()); err != nil {
+		t.Fatalf("first Campaign failed: %v", err)
+	}
+	<-first.Status()
+
+	second := NewEtcdElector(client, "sub-2")
+	secondWon := make(chan struct{})
+	go func() {
+		if err := second.Campaign(context.Background()); err == nil {
+			close(secondWon)
+		}
+	}()
+
+	select {
+	case <-secondWon:
+		t.Fatalf("second elector should not win leadership while the first holds it")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	if err := first.Resign(context.Background()); err != nil {
+		t.Fatalf("Resign failed: %v", err)
+	}
+
+	select {
+	case <-secondWon:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("second elector should win leadership once the first resigns")
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2022 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leaderelection
+
+import "context"
+
+// Standalone is a LeaderElector that wins leadership immediately and never
+// loses it. It is meant for single-node deployments where no coordination
+// is needed, so a trigger worker doesn't have to special-case HA mode.
+type Standalone struct {
+	statusCh chan bool
+}
+
+// NewStandalone creates a LeaderElector that is always the leader.
+func NewStandalone() *Standalone {
+	return &Standalone{statusCh: make(chan bool, 1)}
+}
+
+func (s *Standalone) Campaign(ctx context.Context) error {
+	select {
+	case s.statusCh <- true:
+	default:
+	}
+	return nil
+}
+
+func (s *Standalone) Status() <-chan bool {
+	return s.statusCh
+}
+
+func (s *Standalone) Resign(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	ce "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/linkall-labs/vanus/internal/primitive"
+)
+
+func init() {
+	Register("awssqs", newSQSSender)
+}
+
+// sqsSender delivers events to an AWS SQS queue as a structured-mode
+// CloudEvents JSON body. There is no CloudEvents SQS protocol binding, so
+// this adapter talks to the queue directly.
+type sqsSender struct {
+	client   *sqs.Client
+	queueURL string
+}
+
+// parseSQSURI splits a URI of the form awssqs://region/account-id/queue-name
+// into the region and the account-id/queue-name path used to build the
+// queue URL.
+func parseSQSURI(uri primitive.URI) (region, queuePath string, err error) {
+	u, err := url.Parse(uri.String())
+	if err != nil {
+		return "", "", fmt.Errorf("sink: parse awssqs uri %q: %w", uri, err)
+	}
+	return u.Host, strings.TrimPrefix(u.Path, "/"), nil
+}
+
+// newSQSSender builds a Sender for URIs of the form
+// awssqs://region/account-id/queue-name, mirroring an SQS queue URL.
+func newSQSSender(uri primitive.URI, opts Options) (Sender, error) {
+	region, queuePath, err := parseSQSURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.Background(), config.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("sink: load aws config: %w", err)
+	}
+	if opts.Auth != nil && (opts.Auth.Username != "" || opts.Auth.Password != "" || opts.Auth.Token != "") {
+		cfg.Credentials = aws.NewCredentialsCache(
+			staticCredentials{accessKey: opts.Auth.Username, secretKey: opts.Auth.Password, sessionToken: opts.Auth.Token})
+	}
+	return &sqsSender{
+		client:   sqs.NewFromConfig(cfg),
+		queueURL: fmt.Sprintf("https://sqs.%s.amazonaws.com/%s", region, queuePath),
+	}, nil
+}
+
+func (s *sqsSender) Send(ctx context.Context, event *ce.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("sink: marshal event for sqs: %w", err)
+	}
+	_, err = s.client.SendMessage(ctx, &sqs.SendMessageInput{
+		QueueUrl:    aws.String(s.queueURL),
+		MessageBody: aws.String(string(body)),
+	})
+	return err
+}
+
+type staticCredentials struct {
+	accessKey, secretKey, sessionToken string
+}
+
+func (c staticCredentials) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	return aws.Credentials{
+		AccessKeyID:     c.accessKey,
+		SecretAccessKey: c.secretKey,
+		SessionToken:    c.sessionToken,
+	}, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"encoding/base64"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/linkall-labs/vanus/internal/primitive"
+)
+
+func init() {
+	Register("http", newHTTPSender)
+	Register("https", newHTTPSender)
+}
+
+// httpSender is the original CloudEvents-over-HTTP delivery path, now just
+// one adapter among several registered under the sink package.
+type httpSender struct {
+	client ce.Client
+}
+
+func newHTTPSender(uri primitive.URI, opts Options) (Sender, error) {
+	httpOpts := []ce.HTTPOption{ce.WithTarget(uri.String())}
+	for k, v := range opts.Headers {
+		httpOpts = append(httpOpts, ce.WithHeader(k, v))
+	}
+	if opts.Auth != nil {
+		switch {
+		case opts.Auth.Token != "":
+			httpOpts = append(httpOpts, ce.WithHeader("Authorization", "Bearer "+opts.Auth.Token))
+		case opts.Auth.Username != "" || opts.Auth.Password != "":
+			cred := base64.StdEncoding.EncodeToString([]byte(opts.Auth.Username + ":" + opts.Auth.Password))
+			httpOpts = append(httpOpts, ce.WithHeader("Authorization", "Basic "+cred))
+		}
+	}
+	p, err := ce.NewHTTP(httpOpts...)
+	if err != nil {
+		return nil, err
+	}
+	var copts []ce.ClientOption
+	if opts.ContentMode == ContentModeStructured {
+		copts = append(copts, ce.WithForceStructured())
+	}
+	client, err := ce.NewClient(p, copts...)
+	if err != nil {
+		return nil, err
+	}
+	return &httpSender{client: client}, nil
+}
+
+func (s *httpSender) Send(ctx context.Context, event *ce.Event) error {
+	return s.client.Send(ctx, *event)
+}
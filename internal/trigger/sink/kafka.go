@@ -0,0 +1,94 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	kafka_sarama "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
+	ce "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/linkall-labs/vanus/internal/primitive"
+)
+
+func init() {
+	Register("kafka", newKafkaSender)
+}
+
+// kafkaSender delivers events using the CloudEvents Kafka protocol binding,
+// so subscriptions can fan events into an existing Kafka bus instead of
+// requiring a receiver shim in front of it.
+type kafkaSender struct {
+	client ce.Client
+	sender *kafka_sarama.Sender
+}
+
+// parseKafkaURI splits a URI of the form
+// kafka://topic?brokers=host1:9092,host2:9092 into the topic and the list
+// of brokers.
+func parseKafkaURI(uri primitive.URI) (topic string, brokers []string, err error) {
+	u, err := url.Parse(uri.String())
+	if err != nil {
+		return "", nil, fmt.Errorf("sink: parse kafka uri %q: %w", uri, err)
+	}
+	topic = strings.TrimPrefix(u.Path, "/")
+	if topic == "" {
+		topic = u.Host
+	}
+	return topic, strings.Split(u.Query().Get("brokers"), ","), nil
+}
+
+// newKafkaSender builds a Sender for URIs of the form
+// kafka://topic?brokers=host1:9092,host2:9092.
+func newKafkaSender(uri primitive.URI, opts Options) (Sender, error) {
+	topic, brokers, err := parseKafkaURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	saramaConfig := sarama.NewConfig()
+	if opts.Auth != nil {
+		saramaConfig.Net.SASL.Enable = true
+		saramaConfig.Net.SASL.User = opts.Auth.Username
+		saramaConfig.Net.SASL.Password = opts.Auth.Password
+	}
+	sender, err := kafka_sarama.NewSender(brokers, saramaConfig, topic)
+	if err != nil {
+		return nil, fmt.Errorf("sink: create kafka sender for topic %q: %w", topic, err)
+	}
+	var copts []ce.ClientOption
+	if opts.ContentMode == ContentModeStructured {
+		copts = append(copts, ce.WithForceStructured())
+	} else {
+		copts = append(copts, ce.WithForceBinary())
+	}
+	client, err := ce.NewClient(sender, copts...)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaSender{client: client, sender: sender}, nil
+}
+
+func (s *kafkaSender) Send(ctx context.Context, event *ce.Event) error {
+	return s.client.Send(ctx, *event)
+}
+
+func (s *kafkaSender) Close(ctx context.Context) error {
+	return s.sender.Close(ctx)
+}
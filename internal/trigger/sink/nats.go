@@ -0,0 +1,80 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	cenats "github.com/cloudevents/sdk-go/protocol/nats/v2"
+	ce "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/linkall-labs/vanus/internal/primitive"
+)
+
+func init() {
+	Register("nats", newNatsSender)
+}
+
+// natsSender delivers events over NATS using the CloudEvents NATS protocol
+// binding.
+type natsSender struct {
+	client   ce.Client
+	protocol *cenats.Protocol
+}
+
+// parseNatsURI splits a URI of the form nats://host:port/subject into the
+// host and subject.
+func parseNatsURI(uri primitive.URI) (host, subject string, err error) {
+	u, err := url.Parse(uri.String())
+	if err != nil {
+		return "", "", fmt.Errorf("sink: parse nats uri %q: %w", uri, err)
+	}
+	return u.Host, strings.TrimPrefix(u.Path, "/"), nil
+}
+
+// newNatsSender builds a Sender for URIs of the form nats://host:port/subject.
+func newNatsSender(uri primitive.URI, opts Options) (Sender, error) {
+	host, subject, err := parseNatsURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := cenats.NewProtocol(host, subject, subject, cenats.NatsOptions())
+	if err != nil {
+		return nil, fmt.Errorf("sink: create nats protocol for subject %q: %w", subject, err)
+	}
+	var copts []ce.ClientOption
+	if opts.ContentMode == ContentModeStructured {
+		copts = append(copts, ce.WithForceStructured())
+	} else {
+		copts = append(copts, ce.WithForceBinary())
+	}
+	client, err := ce.NewClient(p, copts...)
+	if err != nil {
+		return nil, err
+	}
+	return &natsSender{client: client, protocol: p}, nil
+}
+
+func (s *natsSender) Send(ctx context.Context, event *ce.Event) error {
+	return s.client.Send(ctx, *event)
+}
+
+func (s *natsSender) Close(ctx context.Context) error {
+	return s.protocol.Close(ctx)
+}
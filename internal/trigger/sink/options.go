@@ -0,0 +1,39 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+// ContentMode selects how a CloudEvent is encoded on the wire.
+type ContentMode string
+
+const (
+	ContentModeBinary     ContentMode = "binary"
+	ContentModeStructured ContentMode = "structured"
+)
+
+// AuthOptions configures credentials for sinks that require them, such as
+// an authenticated message broker or a cloud provider endpoint.
+type AuthOptions struct {
+	Username string
+	Password string
+	Token    string
+}
+
+// Options carries the per-scheme configuration a Sender needs: auth
+// credentials, extra transport headers, and the CloudEvents content mode.
+type Options struct {
+	ContentMode ContentMode
+	Headers     map[string]string
+	Auth        *AuthOptions
+}
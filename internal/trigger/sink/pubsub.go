@@ -0,0 +1,81 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	cepubsub "github.com/cloudevents/sdk-go/protocol/pubsub/v2"
+	ce "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/linkall-labs/vanus/internal/primitive"
+)
+
+func init() {
+	Register("gcppubsub", newPubsubSender)
+}
+
+// pubsubSender delivers events to Google Cloud Pub/Sub using the
+// CloudEvents Pub/Sub protocol binding.
+type pubsubSender struct {
+	client ce.Client
+}
+
+// parsePubsubURI splits a URI of the form gcppubsub://project/topic into
+// the project and topic.
+func parsePubsubURI(uri primitive.URI) (project, topic string, err error) {
+	u, err := url.Parse(uri.String())
+	if err != nil {
+		return "", "", fmt.Errorf("sink: parse gcppubsub uri %q: %w", uri, err)
+	}
+	parts := strings.SplitN(strings.TrimPrefix(u.Path, "/"), "/", 2)
+	project = u.Host
+	if len(parts) > 0 {
+		topic = parts[0]
+	}
+	return project, topic, nil
+}
+
+// newPubsubSender builds a Sender for URIs of the form
+// gcppubsub://project/topic.
+func newPubsubSender(uri primitive.URI, opts Options) (Sender, error) {
+	project, topic, err := parsePubsubURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := cepubsub.New(context.Background(), cepubsub.WithProjectID(project), cepubsub.WithTopicID(topic))
+	if err != nil {
+		return nil, fmt.Errorf("sink: create pubsub protocol for topic %q: %w", topic, err)
+	}
+	var copts []ce.ClientOption
+	if opts.ContentMode == ContentModeStructured {
+		copts = append(copts, ce.WithForceStructured())
+	} else {
+		copts = append(copts, ce.WithForceBinary())
+	}
+	client, err := ce.NewClient(p, copts...)
+	if err != nil {
+		return nil, err
+	}
+	return &pubsubSender{client: client}, nil
+}
+
+func (s *pubsubSender) Send(ctx context.Context, event *ce.Event) error {
+	return s.client.Send(ctx, *event)
+}
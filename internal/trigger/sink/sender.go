@@ -0,0 +1,73 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sink adapts CloudEvents delivery to different destination
+// protocols (HTTP, Kafka, NATS, ...) behind a single Sender interface,
+// selected by the scheme of a subscription's sink URI.
+package sink
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/linkall-labs/vanus/internal/primitive"
+)
+
+// Sender delivers a single CloudEvent to a configured destination.
+type Sender interface {
+	Send(ctx context.Context, event *ce.Event) error
+}
+
+// Closer is implemented by Senders holding resources — connections,
+// producers — that must be released when a trigger stops.
+type Closer interface {
+	Close(ctx context.Context) error
+}
+
+// Factory builds a Sender for a URI whose scheme it is registered under.
+type Factory func(uri primitive.URI, opts Options) (Sender, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{}
+)
+
+// Register associates a URI scheme with a Sender factory. Built-in schemes
+// register themselves from this package's init functions; call Register to
+// add a custom adapter.
+func Register(scheme string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[scheme] = factory
+}
+
+// NewSender looks up the Sender factory registered for uri's scheme and
+// builds a Sender from it.
+func NewSender(uri primitive.URI, opts Options) (Sender, error) {
+	u, err := url.Parse(uri.String())
+	if err != nil {
+		return nil, fmt.Errorf("sink: parse uri %q: %w", uri, err)
+	}
+	registryMu.RLock()
+	factory, ok := registry[u.Scheme]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("sink: no sender registered for scheme %q", u.Scheme)
+	}
+	return factory(uri, opts)
+}
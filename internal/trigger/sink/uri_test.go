@@ -0,0 +1,84 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/linkall-labs/vanus/internal/primitive"
+)
+
+func TestParseKafkaURI(t *testing.T) {
+	topic, brokers, err := parseKafkaURI(primitive.URI("kafka://my-topic?brokers=host1:9092,host2:9092"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if topic != "my-topic" {
+		t.Fatalf("expected topic %q, got %q", "my-topic", topic)
+	}
+	if want := []string{"host1:9092", "host2:9092"}; !reflect.DeepEqual(brokers, want) {
+		t.Fatalf("expected brokers %v, got %v", want, brokers)
+	}
+}
+
+func TestParseKafkaURI_TopicFallsBackToHost(t *testing.T) {
+	topic, _, err := parseKafkaURI(primitive.URI("kafka://my-topic"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if topic != "my-topic" {
+		t.Fatalf("expected topic to fall back to host %q, got %q", "my-topic", topic)
+	}
+}
+
+func TestParseNatsURI(t *testing.T) {
+	host, subject, err := parseNatsURI(primitive.URI("nats://nats.local:4222/my-subject"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if host != "nats.local:4222" {
+		t.Fatalf("expected host %q, got %q", "nats.local:4222", host)
+	}
+	if subject != "my-subject" {
+		t.Fatalf("expected subject %q, got %q", "my-subject", subject)
+	}
+}
+
+func TestParsePubsubURI(t *testing.T) {
+	project, topic, err := parsePubsubURI(primitive.URI("gcppubsub://my-project/my-topic"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if project != "my-project" {
+		t.Fatalf("expected project %q, got %q", "my-project", project)
+	}
+	if topic != "my-topic" {
+		t.Fatalf("expected topic %q, got %q", "my-topic", topic)
+	}
+}
+
+func TestParseSQSURI(t *testing.T) {
+	region, queuePath, err := parseSQSURI(primitive.URI("awssqs://us-east-1/123456789012/my-queue"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if region != "us-east-1" {
+		t.Fatalf("expected region %q, got %q", "us-east-1", region)
+	}
+	if queuePath != "123456789012/my-queue" {
+		t.Fatalf("expected queue path %q, got %q", "123456789012/my-queue", queuePath)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newBackoffTrigger() *Trigger {
+	return &Trigger{
+		config: Config{
+			BackoffBase:       100 * time.Millisecond,
+			BackoffCap:        1 * time.Second,
+			BackoffMultiplier: 2,
+		},
+	}
+}
+
+// TestBackoffDelay_ScalesWithMultiplier checks that each retry's delay
+// roughly doubles, within the +/-50% jitter backoffDelay applies.
+func TestBackoffDelay_ScalesWithMultiplier(t *testing.T) {
+	tr := newBackoffTrigger()
+	bounds := func(base time.Duration) (time.Duration, time.Duration) {
+		return base / 2, base * 3 / 2
+	}
+
+	lo, hi := bounds(100 * time.Millisecond)
+	if d := tr.backoffDelay(1); d < lo || d > hi {
+		t.Fatalf("retry 1: expected delay in [%v, %v], got %v", lo, hi, d)
+	}
+
+	lo, hi = bounds(200 * time.Millisecond)
+	if d := tr.backoffDelay(2); d < lo || d > hi {
+		t.Fatalf("retry 2: expected delay in [%v, %v], got %v", lo, hi, d)
+	}
+}
+
+// TestBackoffDelay_CapsAtBackoffCap checks that delay growth stops at
+// BackoffCap regardless of how many retries have elapsed.
+func TestBackoffDelay_CapsAtBackoffCap(t *testing.T) {
+	tr := newBackoffTrigger()
+	lo, hi := tr.config.BackoffCap/2, tr.config.BackoffCap*3/2
+	if d := tr.backoffDelay(10); d < lo || d > hi {
+		t.Fatalf("expected delay capped around %v, got %v", tr.config.BackoffCap, d)
+	}
+}
+
+// TestHTTPStatusFromErr_NoStatusAvailable checks the fallback for errors
+// that don't carry an HTTP protocol result, e.g. a timeout.
+func TestHTTPStatusFromErr_NoStatusAvailable(t *testing.T) {
+	if got := httpStatusFromErr(errors.New("deadline exceeded")); got != 0 {
+		t.Fatalf("expected 0 for a non-HTTP error, got %d", got)
+	}
+}
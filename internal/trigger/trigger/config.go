@@ -0,0 +1,131 @@
+// Copyright 2022 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/linkall-labs/vanus/internal/trigger/leaderelection"
+	"github.com/linkall-labs/vanus/internal/trigger/sink"
+)
+
+const (
+	defaultBufferSize        = 2048
+	defaultFilterProcessSize = 1
+	defaultSendProcessSize   = 1
+	defaultSendTimeOut       = 5 * time.Second
+	defaultMaxRetryTimes     = 3
+	defaultBackoffBase       = 200 * time.Millisecond
+	defaultBackoffCap        = 30 * time.Second
+	defaultBackoffMultiplier = 2.0
+	defaultRateLimitBurst    = 10
+	defaultMaxInFlight       = 64
+	defaultCampaignTimeout   = 30 * time.Second
+)
+
+// Config controls how a single Trigger processes and delivers events.
+type Config struct {
+	BufferSize        int           `json:"buffer_size"`
+	FilterProcessSize int           `json:"filter_process_size"`
+	SendProcessSize   int           `json:"send_process_size"`
+	SendTimeOut       time.Duration `json:"send_time_out"`
+	MaxRetryTimes     int           `json:"max_retry_times"`
+
+	// BackoffBase is the delay before the first retry. Each subsequent
+	// retry multiplies the previous delay by BackoffMultiplier, capped at
+	// BackoffCap, then jittered by up to +/-50%.
+	BackoffBase       time.Duration `json:"backoff_base"`
+	BackoffCap        time.Duration `json:"backoff_cap"`
+	BackoffMultiplier float64       `json:"backoff_multiplier"`
+
+	// LeaderElector coordinates delivery across replicas of the same
+	// subscription so only the leader runs workers. If nil, initConfig
+	// builds one: an EtcdElector keyed by the subscription ID when
+	// EtcdClient is set, otherwise leaderelection.Standalone (always the
+	// leader) for single-node deployments.
+	LeaderElector leaderelection.LeaderElector `json:"-"`
+
+	// EtcdClient is the cluster's etcd client, reused to elect a leader
+	// among replicas of the same subscription. Only consulted when
+	// LeaderElector is nil; ignored otherwise.
+	EtcdClient *clientv3.Client `json:"-"`
+
+	// CampaignTimeout bounds how long Start waits to acquire leadership
+	// before giving up.
+	CampaignTimeout time.Duration `json:"campaign_timeout"`
+
+	// SinkOptions carries the auth, headers and content-mode settings
+	// passed to the sink.Sender built from a subscription's Sink URI. The
+	// URI scheme (http, kafka, nats, ...) selects which adapter applies
+	// these options.
+	SinkOptions sink.Options `json:"sink_options"`
+
+	// RateLimit throttles how fast events are sent to the sink, so one
+	// noisy subscription can't starve others sharing the same worker.
+	RateLimit RateLimit `json:"rate_limit"`
+}
+
+// RateLimit bounds how fast and how concurrently a Trigger may send events
+// to its sink. RequestsPerSecond <= 0 means unlimited.
+type RateLimit struct {
+	RequestsPerSecond float64 `json:"requests_per_second"`
+	Burst             int     `json:"burst"`
+	MaxInFlight       int     `json:"max_in_flight"`
+}
+
+func (c *Config) initConfig(subID string) {
+	if c.BufferSize <= 0 {
+		c.BufferSize = defaultBufferSize
+	}
+	if c.FilterProcessSize <= 0 {
+		c.FilterProcessSize = defaultFilterProcessSize
+	}
+	if c.SendProcessSize <= 0 {
+		c.SendProcessSize = defaultSendProcessSize
+	}
+	if c.SendTimeOut <= 0 {
+		c.SendTimeOut = defaultSendTimeOut
+	}
+	if c.MaxRetryTimes <= 0 {
+		c.MaxRetryTimes = defaultMaxRetryTimes
+	}
+	if c.BackoffBase <= 0 {
+		c.BackoffBase = defaultBackoffBase
+	}
+	if c.BackoffCap <= 0 {
+		c.BackoffCap = defaultBackoffCap
+	}
+	if c.BackoffMultiplier <= 1 {
+		c.BackoffMultiplier = defaultBackoffMultiplier
+	}
+	if c.LeaderElector == nil {
+		if c.EtcdClient != nil {
+			c.LeaderElector = leaderelection.NewEtcdElector(c.EtcdClient, subID)
+		} else {
+			c.LeaderElector = leaderelection.NewStandalone()
+		}
+	}
+	if c.CampaignTimeout <= 0 {
+		c.CampaignTimeout = defaultCampaignTimeout
+	}
+	if c.RateLimit.Burst <= 0 {
+		c.RateLimit.Burst = defaultRateLimitBurst
+	}
+	if c.RateLimit.MaxInFlight <= 0 {
+		c.RateLimit.MaxInFlight = defaultMaxInFlight
+	}
+}
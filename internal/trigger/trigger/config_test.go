@@ -0,0 +1,69 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"testing"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/linkall-labs/vanus/internal/trigger/leaderelection"
+)
+
+func TestInitConfig_DefaultsToStandaloneWithoutEtcdClient(t *testing.T) {
+	c := &Config{}
+	c.initConfig("sub-1")
+	if _, ok := c.LeaderElector.(*leaderelection.Standalone); !ok {
+		t.Fatalf("expected a Standalone elector when EtcdClient is unset, got %T", c.LeaderElector)
+	}
+}
+
+func TestInitConfig_BuildsEtcdElectorWhenEtcdClientSet(t *testing.T) {
+	// A lazily-dialed client is enough here: initConfig only needs to wire
+	// the elector, not actually connect to etcd.
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   []string{"127.0.0.1:0"},
+		DialTimeout: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("failed to create etcd client: %v", err)
+	}
+	defer client.Close()
+
+	c := &Config{EtcdClient: client}
+	c.initConfig("sub-1")
+	if _, ok := c.LeaderElector.(*leaderelection.EtcdElector); !ok {
+		t.Fatalf("expected an EtcdElector when EtcdClient is set, got %T", c.LeaderElector)
+	}
+}
+
+func TestInitConfig_ExplicitLeaderElectorWins(t *testing.T) {
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   []string{"127.0.0.1:0"},
+		DialTimeout: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("failed to create etcd client: %v", err)
+	}
+	defer client.Close()
+
+	standalone := leaderelection.NewStandalone()
+	c := &Config{EtcdClient: client, LeaderElector: standalone}
+	c.initConfig("sub-1")
+	if c.LeaderElector != standalone {
+		t.Fatalf("expected the explicitly configured LeaderElector to win over EtcdClient")
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2022 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"strconv"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+	"github.com/linkall-labs/vanus/internal/primitive"
+)
+
+const (
+	extSubscriptionID = "vanusdeadlettersubid"
+	extRetryAttempts  = "vanusdeadletterattempts"
+	extLastHTTPStatus = "vanusdeadletterhttpstatus"
+	extLastError      = "vanusdeadlettererror"
+)
+
+// DeadLetterSink forwards events that a Trigger has given up delivering,
+// annotating them with why delivery failed so they can be inspected or
+// replayed later.
+type DeadLetterSink struct {
+	target   primitive.URI
+	ceClient ce.Client
+}
+
+// NewDeadLetterSink creates a DeadLetterSink for the given URI. A zero-value
+// URI means dead-lettering is disabled, in which case it returns a nil
+// *DeadLetterSink and no error.
+func NewDeadLetterSink(target primitive.URI) (*DeadLetterSink, error) {
+	if target == "" {
+		return nil, nil
+	}
+	ceClient, err := primitive.NewCeClient(target)
+	if err != nil {
+		return nil, err
+	}
+	return &DeadLetterSink{target: target, ceClient: ceClient}, nil
+}
+
+// Send delivers e to the dead-letter target, describing the delivery
+// failure that caused it to land here.
+func (d *DeadLetterSink) Send(ctx context.Context, e *ce.Event, subscriptionID string, attempts, lastStatus int, lastErr error) error {
+	out := e.Clone()
+	out.SetExtension(extSubscriptionID, subscriptionID)
+	out.SetExtension(extRetryAttempts, strconv.Itoa(attempts))
+	if lastStatus != 0 {
+		out.SetExtension(extLastHTTPStatus, strconv.Itoa(lastStatus))
+	}
+	if lastErr != nil {
+		out.SetExtension(extLastError, lastErr.Error())
+	}
+	return d.ceClient.Send(ctx, out)
+}
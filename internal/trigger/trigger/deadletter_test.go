@@ -0,0 +1,106 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+	ceprotocol "github.com/cloudevents/sdk-go/v2/protocol"
+)
+
+// fakeCeClient is a minimal ce.Client stand-in that records the last event
+// it was asked to send, or fails if sendErr is set.
+type fakeCeClient struct {
+	ce.Client
+	sendErr error
+	sent    *ce.Event
+}
+
+func (f *fakeCeClient) Send(ctx context.Context, event ce.Event) ceprotocol.Result {
+	if f.sendErr != nil {
+		return f.sendErr
+	}
+	f.sent = &event
+	return nil
+}
+
+func newTestEvent() *ce.Event {
+	e := ce.NewEvent()
+	e.SetID("1")
+	e.SetType("test.type")
+	e.SetSource("test.source")
+	return &e
+}
+
+func TestNewDeadLetterSink_EmptyTargetDisabled(t *testing.T) {
+	d, err := NewDeadLetterSink("")
+	if err != nil || d != nil {
+		t.Fatalf("empty target should yield a nil, error-free sink, got %v, %v", d, err)
+	}
+}
+
+func TestDeadLetterSink_SendAnnotatesAndDelivers(t *testing.T) {
+	fake := &fakeCeClient{}
+	d := &DeadLetterSink{ceClient: fake}
+	event := newTestEvent()
+	cause := errors.New("sink unreachable")
+
+	if err := d.Send(context.Background(), event, "sub-1", 3, 503, cause); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	if fake.sent == nil {
+		t.Fatalf("expected event to be forwarded to the dead-letter target")
+	}
+	if got := fake.sent.Extensions()[extSubscriptionID]; got != "sub-1" {
+		t.Fatalf("expected subscription id extension %q, got %q", "sub-1", got)
+	}
+	if got := fake.sent.Extensions()[extRetryAttempts]; got != "3" {
+		t.Fatalf("expected retry attempts extension %q, got %q", "3", got)
+	}
+	if got := fake.sent.Extensions()[extLastHTTPStatus]; got != "503" {
+		t.Fatalf("expected last http status extension %q, got %q", "503", got)
+	}
+	if got := fake.sent.Extensions()[extLastError]; got != cause.Error() {
+		t.Fatalf("expected last error extension %q, got %q", cause.Error(), got)
+	}
+}
+
+func TestDeadLetterSink_SendOmitsZeroStatusAndNilError(t *testing.T) {
+	fake := &fakeCeClient{}
+	d := &DeadLetterSink{ceClient: fake}
+	event := newTestEvent()
+
+	if err := d.Send(context.Background(), event, "sub-1", 3, 0, nil); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	if _, ok := fake.sent.Extensions()[extLastHTTPStatus]; ok {
+		t.Fatalf("expected no http status extension when lastStatus is 0")
+	}
+	if _, ok := fake.sent.Extensions()[extLastError]; ok {
+		t.Fatalf("expected no error extension when lastErr is nil")
+	}
+}
+
+func TestDeadLetterSink_SendPropagatesDeliveryError(t *testing.T) {
+	fake := &fakeCeClient{sendErr: errors.New("dead letter target down")}
+	d := &DeadLetterSink{ceClient: fake}
+
+	if err := d.Send(context.Background(), newTestEvent(), "sub-1", 1, 0, nil); err == nil {
+		t.Fatalf("expected Send to propagate the underlying delivery error")
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/linkall-labs/vanus/internal/trigger/info"
+)
+
+// fakeElector is a minimal LeaderElector whose Status channel is driven
+// directly by the test, standing in for the buffered channel shape of
+// leaderelection.EtcdElector.
+type fakeElector struct {
+	statusCh chan bool
+}
+
+func newFakeElector() *fakeElector {
+	return &fakeElector{statusCh: make(chan bool, 2)}
+}
+
+func (f *fakeElector) Campaign(ctx context.Context) error { return nil }
+func (f *fakeElector) Status() <-chan bool                { return f.statusCh }
+func (f *fakeElector) Resign(ctx context.Context) error   { return nil }
+
+// TestWatchLeadership_SurvivesRepeatedTrue guards against the single-select
+// bug where watchLeadership returned on the very first status value: since
+// a real elector sends true immediately after winning the campaign,
+// returning there meant a later leadership loss was never observed.
+func TestWatchLeadership_SurvivesRepeatedTrue(t *testing.T) {
+	fe := newFakeElector()
+	fe.statusCh <- true
+	fe.statusCh <- true
+
+	tr := &Trigger{
+		SubscriptionID: "sub-1",
+		eventCh:        make(chan info.EventRecord),
+		sendCh:         make(chan info.EventRecord),
+		config:         Config{LeaderElector: fe},
+	}
+	tr.stop = func() {}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		tr.watchLeadership(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("watchLeadership returned after a leading=true status, it should keep watching")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	fe.statusCh <- false
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("watchLeadership did not stop the trigger after leadership was lost")
+	}
+
+	// watchLeadership stops the trigger via "go t.Stop()", so it may still
+	// be running doStop when watchLeadership itself returns; poll instead
+	// of asserting immediately.
+	waitForState(t, tr, TriggerStopped)
+}
+
+// waitForState polls tr's state until it matches want or the deadline
+// expires, failing the test in the latter case.
+func waitForState(t *testing.T, tr *Trigger, want TriggerState) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if tr.GetState() == want {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("expected trigger state %q, got %q", want, tr.GetState())
+}
+
+// TestStop_IsSafeConcurrentlyFromTwoCallers guards the double-close panic
+// when watchLeadership's own `go t.Stop()` races an external Stop() call.
+func TestStop_IsSafeConcurrentlyFromTwoCallers(t *testing.T) {
+	fe := newFakeElector()
+	tr := &Trigger{
+		SubscriptionID: "sub-2",
+		eventCh:        make(chan info.EventRecord),
+		sendCh:         make(chan info.EventRecord),
+		config:         Config{LeaderElector: fe},
+	}
+	tr.stop = func() {}
+
+	done := make(chan struct{}, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			tr.Stop()
+			done <- struct{}{}
+		}()
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("concurrent Stop calls did not both return")
+		}
+	}
+	waitForState(t, tr, TriggerStopped)
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"net/http"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// subscriptionIDLabel is the common label used across trigger metrics so
+// they can be sliced per subscription in Grafana/alerting.
+const subscriptionIDLabel = "subscription_id"
+
+var (
+	eventsReceivedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "vanus_trigger_events_received_total",
+		Help: "Number of events a Trigger has received from its subscription.",
+	}, []string{subscriptionIDLabel})
+
+	eventsFilteredTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "vanus_trigger_events_filtered_total",
+		Help: "Number of events a Trigger has dropped because they failed the subscription filter.",
+	}, []string{subscriptionIDLabel})
+
+	eventsSentTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "vanus_trigger_events_sent_total",
+		Help: "Number of events a Trigger has attempted to deliver to its sink, by outcome.",
+	}, []string{subscriptionIDLabel, "result"})
+
+	sendDurationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "vanus_trigger_send_duration_seconds",
+		Help:    "Latency of a single sink delivery attempt, including failed attempts.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{subscriptionIDLabel})
+
+	eventChDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vanus_trigger_eventch_depth",
+		Help: "Number of events buffered between subscription and filter processing.",
+	}, []string{subscriptionIDLabel})
+
+	sendChDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vanus_trigger_sendch_depth",
+		Help: "Number of events buffered between filter processing and sink delivery.",
+	}, []string{subscriptionIDLabel})
+
+	retriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "vanus_trigger_retries_total",
+		Help: "Number of sink delivery retries a Trigger has performed.",
+	}, []string{subscriptionIDLabel})
+
+	triggerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vanus_trigger_state",
+		Help: "Current state of a Trigger, 1 for the active state and 0 for all others.",
+	}, []string{subscriptionIDLabel, "state"})
+)
+
+// allTriggerStates lists every TriggerState value, so setTriggerStateMetric
+// can zero out the states a Trigger just left.
+var allTriggerStates = []TriggerState{
+	TriggerCreated, TriggerPending, TriggerRunning, TriggerSleep,
+	TriggerPaused, TriggerStopped, TriggerDestroyed,
+}
+
+// setTriggerStateMetric publishes vanus_trigger_state as a gauge enum: the
+// series for state is set to 1, every other known state for this
+// subscription is set to 0.
+func setTriggerStateMetric(subscriptionID string, state TriggerState) {
+	for _, s := range allTriggerStates {
+		v := 0.0
+		if s == state {
+			v = 1
+		}
+		triggerState.WithLabelValues(subscriptionID, string(s)).Set(v)
+	}
+}
+
+// Handler serves the process's registered Prometheus collectors. Nothing in
+// this repository mounts it on an HTTP server.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+var tracer = otel.Tracer("github.com/linkall-labs/vanus/internal/trigger/trigger")
+
+// sendWithTrace wraps a single send attempt in an OpenTelemetry span and
+// propagates the active trace context onto e via the CloudEvents
+// "traceparent" extension, so the sink side of a delivery can be correlated
+// with the trigger side.
+func (t *Trigger) sendWithTrace(ctx context.Context, e *ce.Event) error {
+	ctx, span := tracer.Start(ctx, "trigger.send", trace.WithAttributes(
+		attribute.String(subscriptionIDLabel, t.SubscriptionID),
+		attribute.String("sink.target", t.Target.String()),
+	))
+	defer span.End()
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if tp := carrier.Get("traceparent"); tp != "" {
+		e.SetExtension("traceparent", tp)
+	}
+	err := t.sender.Send(ctx, e)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
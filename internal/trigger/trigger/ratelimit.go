@@ -0,0 +1,150 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/linkall-labs/vanus/observability/log"
+)
+
+// backpressureCooldown is how long an effective rate stays halved after the
+// sink signals it is overloaded, before it is allowed to recover.
+const backpressureCooldown = 30 * time.Second
+
+// minEffectiveRate is the floor the effective rate halves down to under
+// repeated overload, so it never decays to 0.
+const minEffectiveRate = 0.1
+
+// Stats is a point-in-time snapshot of a Trigger's send throttling, surfaced
+// so the controller can see why a subscription is falling behind.
+type Stats struct {
+	Rate        float64          `json:"rate"`
+	InFlight    int              `json:"in_flight"`
+	DropReasons map[string]int64 `json:"drop_reasons"`
+}
+
+// rateLimiter bounds how fast and how concurrently a Trigger sends events to
+// its sink, and backs off further when the sink reports it is overloaded.
+type rateLimiter struct {
+	limiter *rate.Limiter
+	sem     chan struct{}
+
+	mu          sync.Mutex
+	baseRate    float64
+	currentRate float64
+	cooldownEnd time.Time
+
+	inFlight    int32
+	dropReasons sync.Map // reason string -> *int64
+}
+
+func newRateLimiter(cfg RateLimit) *rateLimiter {
+	limit := rate.Limit(cfg.RequestsPerSecond)
+	if cfg.RequestsPerSecond <= 0 {
+		limit = rate.Inf
+	}
+	r := &rateLimiter{
+		limiter:     rate.NewLimiter(limit, cfg.Burst),
+		sem:         make(chan struct{}, cfg.MaxInFlight),
+		baseRate:    cfg.RequestsPerSecond,
+		currentRate: cfg.RequestsPerSecond,
+	}
+	return r
+}
+
+// Acquire blocks until a send slot is available under both the rate limit
+// and the in-flight cap, or ctx is done first.
+func (r *rateLimiter) Acquire(ctx context.Context) error {
+	select {
+	case r.sem <- struct{}{}:
+	case <-ctx.Done():
+		r.recordDrop("in_flight_wait_cancelled")
+		return ctx.Err()
+	}
+	if err := r.limiter.Wait(ctx); err != nil {
+		<-r.sem
+		r.recordDrop("rate_limit_wait_cancelled")
+		return err
+	}
+	atomic.AddInt32(&r.inFlight, 1)
+	return nil
+}
+
+// Release frees the slot acquired by a matching Acquire call.
+func (r *rateLimiter) Release() {
+	atomic.AddInt32(&r.inFlight, -1)
+	<-r.sem
+}
+
+// OnResult adjusts the effective rate based on a send's last observed HTTP
+// status: repeated 429/503 halves it, floored at minEffectiveRate, for
+// backpressureCooldown, after which it is restored to baseRate. A no-op
+// when RequestsPerSecond is unconfigured (baseRate <= 0); the in-flight
+// semaphore still bounds concurrency in that mode.
+func (r *rateLimiter) OnResult(ctx context.Context, lastStatus int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.baseRate <= 0 {
+		return
+	}
+	now := time.Now()
+	if lastStatus == 429 || lastStatus == 503 {
+		r.currentRate /= 2
+		if r.currentRate < minEffectiveRate {
+			r.currentRate = minEffectiveRate
+		}
+		r.cooldownEnd = now.Add(backpressureCooldown)
+		r.limiter.SetLimit(rate.Limit(r.currentRate))
+		log.Info(ctx, "sink signalled overload, halving trigger send rate", map[string]interface{}{
+			"status": lastStatus, "rate": r.currentRate,
+		})
+		return
+	}
+	if !r.cooldownEnd.IsZero() && now.After(r.cooldownEnd) && r.currentRate < r.baseRate {
+		r.currentRate = r.baseRate
+		r.cooldownEnd = time.Time{}
+		r.limiter.SetLimit(rate.Limit(r.currentRate))
+		log.Info(ctx, "cooldown elapsed, restoring trigger send rate", map[string]interface{}{
+			"rate": r.currentRate,
+		})
+	}
+}
+
+func (r *rateLimiter) recordDrop(reason string) {
+	v, _ := r.dropReasons.LoadOrStore(reason, new(int64))
+	atomic.AddInt64(v.(*int64), 1)
+}
+
+func (r *rateLimiter) Stats() Stats {
+	r.mu.Lock()
+	currentRate := r.currentRate
+	r.mu.Unlock()
+	drops := map[string]int64{}
+	r.dropReasons.Range(func(k, v interface{}) bool {
+		drops[k.(string)] = atomic.LoadInt64(v.(*int64))
+		return true
+	})
+	return Stats{
+		Rate:        currentRate,
+		InFlight:    int(atomic.LoadInt32(&r.inFlight)),
+		DropReasons: drops,
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterOnResult_UnlimitedIsNoop(t *testing.T) {
+	r := newRateLimiter(RateLimit{RequestsPerSecond: 0, Burst: 10, MaxInFlight: 4})
+	r.OnResult(context.Background(), 503)
+	r.OnResult(context.Background(), 503)
+	if got := r.Stats().Rate; got != 0 {
+		t.Fatalf("unlimited rate should stay 0, got %v", got)
+	}
+	if err := r.limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("unlimited limiter must never block: %v", err)
+	}
+}
+
+func TestRateLimiterOnResult_HalvesWithFloor(t *testing.T) {
+	r := newRateLimiter(RateLimit{RequestsPerSecond: 1, Burst: 1, MaxInFlight: 4})
+	for i := 0; i < 10; i++ {
+		r.OnResult(context.Background(), 429)
+	}
+	if got := r.Stats().Rate; got != minEffectiveRate {
+		t.Fatalf("expected rate to floor at %v after repeated overload, got %v", minEffectiveRate, got)
+	}
+}
+
+func TestRateLimiterOnResult_RestoresAfterCooldown(t *testing.T) {
+	r := newRateLimiter(RateLimit{RequestsPerSecond: 10, Burst: 1, MaxInFlight: 4})
+	r.OnResult(context.Background(), 429)
+	if got := r.Stats().Rate; got != 5 {
+		t.Fatalf("expected rate halved to 5, got %v", got)
+	}
+	r.mu.Lock()
+	r.cooldownEnd = time.Now().Add(-time.Second)
+	r.mu.Unlock()
+	r.OnResult(context.Background(), 200)
+	if got := r.Stats().Rate; got != 10 {
+		t.Fatalf("expected rate restored to baseRate 10 after cooldown, got %v", got)
+	}
+}
+
+func TestRateLimiterAcquireRelease_BoundsInFlight(t *testing.T) {
+	r := newRateLimiter(RateLimit{RequestsPerSecond: 0, Burst: 10, MaxInFlight: 1})
+	if err := r.Acquire(context.Background()); err != nil {
+		t.Fatalf("first acquire should not block: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := r.Acquire(ctx); err == nil {
+		t.Fatalf("second acquire should block until the in-flight slot is released")
+	}
+	if got := r.Stats().DropReasons["in_flight_wait_cancelled"]; got != 1 {
+		t.Fatalf("expected one in_flight_wait_cancelled drop, got %d", got)
+	}
+	r.Release()
+	if err := r.Acquire(context.Background()); err != nil {
+		t.Fatalf("acquire after release should succeed: %v", err)
+	}
+}
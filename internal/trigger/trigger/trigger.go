@@ -16,16 +16,21 @@ package trigger
 
 import (
 	"context"
+	"math/rand"
+	"sync"
+	"time"
+
 	ce "github.com/cloudevents/sdk-go/v2"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
 	"github.com/google/uuid"
 	"github.com/linkall-labs/vanus/internal/primitive"
+	"github.com/linkall-labs/vanus/internal/primitive/transform"
 	"github.com/linkall-labs/vanus/internal/trigger/filter"
 	"github.com/linkall-labs/vanus/internal/trigger/info"
 	"github.com/linkall-labs/vanus/internal/trigger/offset"
+	"github.com/linkall-labs/vanus/internal/trigger/sink"
 	"github.com/linkall-labs/vanus/internal/util"
 	"github.com/linkall-labs/vanus/observability/log"
-	"sync"
-	"time"
 )
 
 type TriggerState string
@@ -49,13 +54,17 @@ type Trigger struct {
 	state      TriggerState
 	stateMutex sync.RWMutex
 	lastActive time.Time
+	stopOnce   sync.Once
 
 	offsetManager *offset.SubscriptionOffset
 	stop          context.CancelFunc
 	eventCh       chan info.EventRecord
 	sendCh        chan info.EventRecord
-	ceClient      ce.Client
+	sender        sink.Sender
+	deadLetter    *DeadLetterSink
 	filter        filter.Filter
+	transform     *transform.Pipeline
+	rateLimiter   *rateLimiter
 	config        Config
 
 	wg util.Group
@@ -65,7 +74,21 @@ func NewTrigger(config *Config, sub *primitive.Subscription, offsetManager *offs
 	if config == nil {
 		config = &Config{}
 	}
-	config.initConfig()
+	config.initConfig(sub.ID)
+	deadLetter, err := NewDeadLetterSink(sub.DeadLetter)
+	if err != nil {
+		log.Warning(context.Background(), "init dead letter sink failed, dead lettering disabled", map[string]interface{}{
+			"subId":      sub.ID,
+			log.KeyError: err,
+		})
+	}
+	pipeline, err := transform.NewPipelineFromSpec(sub.Transformer)
+	if err != nil {
+		log.Warning(context.Background(), "init transform pipeline failed, transforms disabled", map[string]interface{}{
+			"subId":      sub.ID,
+			log.KeyError: err,
+		})
+	}
 	return &Trigger{
 		config:         *config,
 		ID:             uuid.New().String(),
@@ -77,42 +100,90 @@ func NewTrigger(config *Config, sub *primitive.Subscription, offsetManager *offs
 		eventCh:        make(chan info.EventRecord, config.BufferSize),
 		sendCh:         make(chan info.EventRecord, config.BufferSize),
 		offsetManager:  offsetManager,
+		deadLetter:     deadLetter,
+		transform:      pipeline,
+		rateLimiter:    newRateLimiter(config.RateLimit),
 	}
 }
 
+// Stats reports the Trigger's current send rate, in-flight count and drop
+// reasons, so the controller can surface them.
+func (t *Trigger) Stats() Stats {
+	return t.rateLimiter.Stats()
+}
+
 func (t *Trigger) EventArrived(ctx context.Context, event info.EventRecord) error {
 	select {
 	case t.eventCh <- event:
 		t.offsetManager.EventReceive(event.OffsetInfo)
+		eventsReceivedTotal.WithLabelValues(t.SubscriptionID).Inc()
 		return nil
 	case <-ctx.Done():
 		return ctx.Err()
 	}
 }
 
-func (t *Trigger) retrySendEvent(ctx context.Context, e *ce.Event) error {
+// backoffDelay returns the delay before the retryTimes-th retry, following
+// exponential backoff capped at config.BackoffCap and jittered by +/-50% to
+// avoid retry storms across triggers sharing a sink.
+func (t *Trigger) backoffDelay(retryTimes int) time.Duration {
+	delay := float64(t.config.BackoffBase)
+	for i := 1; i < retryTimes; i++ {
+		delay *= t.config.BackoffMultiplier
+	}
+	if capped := float64(t.config.BackoffCap); delay > capped {
+		delay = capped
+	}
+	jitter := delay * (rand.Float64() - 0.5)
+	return time.Duration(delay + jitter)
+}
+
+// retrySendEvent attempts to deliver e, retrying with backoff up to
+// config.MaxRetryTimes. It returns the last send error and, when available,
+// the last observed HTTP status code.
+func (t *Trigger) retrySendEvent(ctx context.Context, e *ce.Event) (error, int) {
 	retryTimes := 0
 	doFunc := func() error {
 		timeout, cancel := context.WithTimeout(ctx, t.config.SendTimeOut)
 		defer cancel()
-		return t.ceClient.Send(timeout, *e)
+		start := time.Now()
+		err := t.sendWithTrace(timeout, e)
+		sendDurationSeconds.WithLabelValues(t.SubscriptionID).Observe(time.Since(start).Seconds())
+		return err
 	}
 	var err error
 	for retryTimes < t.config.MaxRetryTimes {
 		retryTimes++
+		if retryTimes > 1 {
+			retriesTotal.WithLabelValues(t.SubscriptionID).Inc()
+		}
 		if err = doFunc(); !ce.IsACK(err) {
 			log.Debug(ctx, "process event error", map[string]interface{}{
 				"error": err, "retryTimes": retryTimes,
 			})
-			time.Sleep(t.config.RetryPeriod)
+			if retryTimes < t.config.MaxRetryTimes {
+				time.Sleep(t.backoffDelay(retryTimes))
+			}
 		} else {
 			log.Debug(ctx, "send ce event success", map[string]interface{}{
 				"event": e,
 			})
-			return nil
+			eventsSentTotal.WithLabelValues(t.SubscriptionID, "success").Inc()
+			return nil, 0
 		}
 	}
-	return err
+	eventsSentTotal.WithLabelValues(t.SubscriptionID, "failure").Inc()
+	return err, httpStatusFromErr(err)
+}
+
+// httpStatusFromErr extracts the HTTP status code from a CloudEvents HTTP
+// protocol error, returning 0 when none is available.
+func httpStatusFromErr(err error) int {
+	var result *cehttp.Result
+	if ce.ResultAs(err, &result) {
+		return result.StatusCode
+	}
+	return 0
 }
 
 func (t *Trigger) runEventProcess(ctx context.Context) {
@@ -126,9 +197,16 @@ func (t *Trigger) runEventProcess(ctx context.Context) {
 				return
 			}
 			if res := filter.FilterEvent(t.filter, *event.Event); res == filter.FailFilter {
+				eventsFilteredTotal.WithLabelValues(t.SubscriptionID).Inc()
 				t.offsetManager.EventCommit(event.OffsetInfo)
 				continue
 			}
+			if t.transform != nil {
+				if err := t.transform.Execute(event.Event); err != nil {
+					t.sendToDeadLetter(ctx, event, 0, err)
+					continue
+				}
+			}
 			t.sendCh <- event
 		}
 	}
@@ -143,18 +221,55 @@ func (t *Trigger) runEventSend(ctx context.Context) {
 			if !ok {
 				return
 			}
-			err := t.retrySendEvent(ctx, event.Event)
-			if err != nil {
-				log.Error(ctx, "send event to sink failed", map[string]interface{}{
-					log.KeyError: err,
-					"event":      event,
-				})
+			if err := t.rateLimiter.Acquire(ctx); err != nil {
+				// Trigger is stopping; leave the event uncommitted so it is
+				// redelivered.
+				return
+			}
+			err, lastStatus := t.retrySendEvent(ctx, event.Event)
+			t.rateLimiter.OnResult(ctx, lastStatus)
+			t.rateLimiter.Release()
+			if err == nil {
+				t.offsetManager.EventCommit(event.OffsetInfo)
+				continue
 			}
-			t.offsetManager.EventCommit(event.OffsetInfo)
+			t.sendToDeadLetter(ctx, event, lastStatus, err)
 		}
 	}
 }
 
+// sendToDeadLetter forwards event to the configured dead-letter sink after
+// it has been given up on, either because delivery exhausted its retries or
+// because transforming it failed. The offset is only committed once the
+// event has landed somewhere: the dead-letter sink, or nowhere if none is
+// configured, matching the prior best-effort behaviour.
+func (t *Trigger) sendToDeadLetter(ctx context.Context, event info.EventRecord, lastStatus int, cause error) {
+	log.Error(ctx, "giving up on event, forwarding to dead letter", map[string]interface{}{
+		log.KeyError: cause,
+		"event":      event,
+	})
+	if t.deadLetter == nil {
+		// No dead-letter sink configured: commit and drop, preserving the
+		// prior behaviour.
+		t.offsetManager.EventCommit(event.OffsetInfo)
+		return
+	}
+	if dlqErr := t.deadLetter.Send(ctx, event.Event, t.SubscriptionID, t.config.MaxRetryTimes, lastStatus, cause); dlqErr != nil {
+		log.Error(ctx, "write to dead letter sink failed, re-queueing event", map[string]interface{}{
+			log.KeyError: dlqErr,
+			"event":      event,
+		})
+		select {
+		case t.sendCh <- event:
+		case <-ctx.Done():
+			// Trigger is stopping and nobody is left to drain sendCh; leave
+			// the event uncommitted so it is redelivered.
+		}
+		return
+	}
+	t.offsetManager.EventCommit(event.OffsetInfo)
+}
+
 func (t *Trigger) runSleepWatch(ctx context.Context) {
 	tk := time.NewTicker(10 * time.Millisecond)
 	defer tk.Stop()
@@ -163,6 +278,8 @@ func (t *Trigger) runSleepWatch(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-tk.C:
+			eventChDepth.WithLabelValues(t.SubscriptionID).Set(float64(len(t.eventCh)))
+			sendChDepth.WithLabelValues(t.SubscriptionID).Set(float64(len(t.sendCh)))
 			t.stateMutex.Lock()
 			if t.state == TriggerRunning {
 				if time.Now().Sub(t.lastActive) > t.SleepDuration {
@@ -171,19 +288,34 @@ func (t *Trigger) runSleepWatch(ctx context.Context) {
 					t.state = TriggerRunning
 				}
 			}
+			setTriggerStateMetric(t.SubscriptionID, t.state)
 			t.stateMutex.Unlock()
 		}
 	}
 }
 
 func (t *Trigger) Start() error {
-	ceClient, err := primitive.NewCeClient(t.Target)
+	sender, err := sink.NewSender(t.Target, t.config.SinkOptions)
 	if err != nil {
 		return err
 	}
-	t.ceClient = ceClient
+	t.sender = sender
 	ctx, cancel := context.WithCancel(context.Background())
 	t.stop = cancel
+
+	// Workers stay parked until leadership over SubscriptionID is acquired.
+	// Campaign uses its own bounded context so a losing replica can't block
+	// Start forever.
+	campaignCtx, campaignCancel := context.WithTimeout(ctx, t.config.CampaignTimeout)
+	err = t.config.LeaderElector.Campaign(campaignCtx)
+	campaignCancel()
+	if err != nil {
+		cancel()
+		t.closeSender(context.Background())
+		return err
+	}
+	t.wg.StartWithContext(ctx, t.watchLeadership)
+
 	for i := 0; i < t.config.FilterProcessSize; i++ {
 		t.wg.StartWithContext(ctx, t.runEventProcess)
 	}
@@ -192,24 +324,74 @@ func (t *Trigger) Start() error {
 	}
 	t.wg.StartWithContext(ctx, t.runSleepWatch)
 
+	t.stateMutex.Lock()
 	t.state = TriggerRunning
 	t.lastActive = time.Now()
+	t.stateMutex.Unlock()
+	setTriggerStateMetric(t.SubscriptionID, t.state)
 	return nil
 }
 
+// watchLeadership stops the trigger as soon as it loses leadership, so a
+// demoted replica can't keep delivering events alongside the new leader.
+func (t *Trigger) watchLeadership(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case leading, ok := <-t.config.LeaderElector.Status():
+			if ok && leading {
+				continue
+			}
+			log.Info(ctx, "lost leadership, stopping trigger", map[string]interface{}{
+				"subId": t.SubscriptionID,
+			})
+			go t.Stop()
+			return
+		}
+	}
+}
+
 func (t *Trigger) Stop() {
+	t.stopOnce.Do(t.doStop)
+}
+
+// closeSender releases t.sender's underlying resources, if it holds any.
+// Called both from doStop and from Start's Campaign-failure path, where a
+// standby replica never gets as far as doStop but still opened a sender.
+func (t *Trigger) closeSender(ctx context.Context) {
+	if closer, ok := t.sender.(sink.Closer); ok {
+		if err := closer.Close(ctx); err != nil {
+			log.Warning(ctx, "close sink sender failed", map[string]interface{}{
+				"subId":      t.SubscriptionID,
+				log.KeyError: err,
+			})
+		}
+	}
+}
+
+// doStop runs the shutdown exactly once; watchLeadership and an external
+// caller can both reach Stop concurrently.
+func (t *Trigger) doStop() {
 	ctx := context.Background()
 	log.Info(ctx, "trigger stop...", map[string]interface{}{
 		"subId": t.SubscriptionID,
 	})
-	if t.state == TriggerStopped {
-		return
-	}
 	t.stop()
 	t.wg.Wait()
+	if err := t.config.LeaderElector.Resign(ctx); err != nil {
+		log.Warning(ctx, "resign leadership failed", map[string]interface{}{
+			"subId":      t.SubscriptionID,
+			log.KeyError: err,
+		})
+	}
+	t.closeSender(ctx)
 	close(t.eventCh)
 	close(t.sendCh)
+	t.stateMutex.Lock()
 	t.state = TriggerStopped
+	t.stateMutex.Unlock()
+	setTriggerStateMetric(t.SubscriptionID, t.state)
 	log.Info(ctx, "trigger stopped", map[string]interface{}{
 		"subId": t.SubscriptionID,
 	})
@@ -219,4 +401,4 @@ func (t *Trigger) GetState() TriggerState {
 	t.stateMutex.RLock()
 	defer t.stateMutex.RUnlock()
 	return t.state
-}
\ No newline at end of file
+}
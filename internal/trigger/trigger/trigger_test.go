@@ -0,0 +1,101 @@
+// Copyright 2023 Linkall Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/linkall-labs/vanus/internal/primitive"
+	"github.com/linkall-labs/vanus/internal/trigger/info"
+	"github.com/linkall-labs/vanus/internal/trigger/sink"
+)
+
+// closeTrackingSender is a sink.Sender and sink.Closer that records whether
+// Close was called, standing in for a real adapter's connection/producer.
+type closeTrackingSender struct {
+	closed bool
+}
+
+func (s *closeTrackingSender) Send(ctx context.Context, event *ce.Event) error { return nil }
+func (s *closeTrackingSender) Close(ctx context.Context) error {
+	s.closed = true
+	return nil
+}
+
+// failingElector always fails Campaign, standing in for a losing replica in
+// leader election.
+type failingElector struct{}
+
+func (failingElector) Campaign(ctx context.Context) error { return errors.New("lost campaign") }
+func (failingElector) Status() <-chan bool                { return make(chan bool) }
+func (failingElector) Resign(ctx context.Context) error   { return nil }
+
+func TestSendToDeadLetter_RequeuesOnDLQFailure(t *testing.T) {
+	tr := &Trigger{
+		sendCh:     make(chan info.EventRecord, 1),
+		deadLetter: &DeadLetterSink{ceClient: &fakeCeClient{sendErr: errors.New("dlq down")}},
+	}
+	event := info.EventRecord{Event: newTestEvent()}
+
+	tr.sendToDeadLetter(context.Background(), event, 503, errors.New("sink down"))
+
+	select {
+	case got := <-tr.sendCh:
+		if got.Event.ID() != event.Event.ID() {
+			t.Fatalf("expected the same event to be requeued")
+		}
+	default:
+		t.Fatalf("expected event to be requeued onto sendCh")
+	}
+}
+
+func TestSendToDeadLetter_AbandonsRequeueOnCancel(t *testing.T) {
+	// Buffer of 0 with nothing to receive means the requeue send would
+	// block forever if it weren't selecting on ctx.Done() too.
+	tr := &Trigger{
+		sendCh:     make(chan info.EventRecord),
+		deadLetter: &DeadLetterSink{ceClient: &fakeCeClient{sendErr: errors.New("dlq down")}},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Must return promptly instead of blocking forever on the unbuffered,
+	// undrained sendCh.
+	tr.sendToDeadLetter(ctx, info.EventRecord{Event: newTestEvent()}, 503, errors.New("sink down"))
+}
+
+func TestStart_ClosesSenderWhenCampaignFails(t *testing.T) {
+	sender := &closeTrackingSender{}
+	sink.Register("faketest-closeonloss", func(uri primitive.URI, opts sink.Options) (sink.Sender, error) {
+		return sender, nil
+	})
+
+	tr := &Trigger{
+		Target: primitive.URI("faketest-closeonloss://somewhere"),
+		config: Config{LeaderElector: failingElector{}, CampaignTimeout: time.Second},
+	}
+
+	if err := tr.Start(); err == nil {
+		t.Fatalf("expected Start to fail when Campaign loses")
+	}
+	if !sender.closed {
+		t.Fatalf("expected the sink sender to be closed when a replica loses its campaign")
+	}
+}